@@ -0,0 +1,57 @@
+package kits
+
+import "context"
+
+// InitContext is passed to kits that implement Init(ctx *InitContext) error,
+// giving them access to the cancellation context and properties the host
+// program passed to Init, as well as the Registry they're being initialized
+// on - so a kit can safely Find its dependencies from within its own Init.
+type InitContext struct {
+    Context    context.Context
+    Properties map[string]interface{}
+    Registry   *Registry
+}
+
+// Property returns the named property and whether it was set via
+// WithProperty or WithProperties.
+func (ic *InitContext) Property(key string) (interface{}, bool) {
+    v, ok := ic.Properties[key]
+    return v, ok
+}
+
+// InitOption configures the InitContext passed to kits during Init.
+type InitOption func(*InitContext)
+
+// WithContext sets the context.Context propagated to kits during Init,
+// instead of the default context.Background(). Init aborts remaining kit
+// initialization as soon as the context is done.
+func WithContext(ctx context.Context) InitOption {
+    return func(ic *InitContext) {
+        ic.Context = ctx
+    }
+}
+
+// WithProperty sets a single property made available to kits during Init,
+// for example a connection string or feature flag, without every kit having
+// to reinvent its own env-var parsing.
+func WithProperty(key string, value interface{}) InitOption {
+    return func(ic *InitContext) {
+        if ic.Properties == nil {
+            ic.Properties = map[string]interface{}{}
+        }
+        ic.Properties[key] = value
+    }
+}
+
+// WithProperties merges props into the properties made available to kits
+// during Init.
+func WithProperties(props map[string]interface{}) InitOption {
+    return func(ic *InitContext) {
+        if ic.Properties == nil {
+            ic.Properties = map[string]interface{}{}
+        }
+        for k, v := range props {
+            ic.Properties[k] = v
+        }
+    }
+}
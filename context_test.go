@@ -0,0 +1,89 @@
+package kits
+
+import (
+    "context"
+    "errors"
+    "testing"
+)
+
+type propKit struct {
+    got string
+    ok  bool
+}
+
+func (k *propKit) Init(ic *InitContext) error {
+    v, ok := ic.Property("key")
+    k.ok = ok
+    if ok {
+        k.got, _ = v.(string)
+    }
+    return nil
+}
+
+func TestInitPassesProperty(t *testing.T) {
+    r := NewRegistry()
+    pk := &propKit{}
+    r.Register(pk)
+
+    if err := r.Init(WithProperty("key", "value")); err != nil {
+        t.Fatalf("Init: %v", err)
+    }
+    if !pk.ok || pk.got != "value" {
+        t.Fatalf("Property(\"key\") = (%q, %v), want (\"value\", true)", pk.got, pk.ok)
+    }
+}
+
+func TestInitPassesProperties(t *testing.T) {
+    r := NewRegistry()
+    pk := &propKit{}
+    r.Register(pk)
+
+    opt := WithProperties(map[string]interface{}{"key": "merged"})
+    if err := r.Init(opt); err != nil {
+        t.Fatalf("Init: %v", err)
+    }
+    if !pk.ok || pk.got != "merged" {
+        t.Fatalf("Property(\"key\") = (%q, %v), want (\"merged\", true)", pk.got, pk.ok)
+    }
+}
+
+type trackedInitKit struct{ inited *bool }
+
+func (k *trackedInitKit) Init() { *k.inited = true }
+
+func TestInitAbortsOnCancelledContext(t *testing.T) {
+    r := NewRegistry()
+    inited := false
+    r.Register(&trackedInitKit{inited: &inited})
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    if err := r.Init(WithContext(ctx)); err == nil {
+        t.Fatal("Init() with an already-cancelled context: got nil error")
+    }
+    if inited {
+        t.Fatal("kit was initialized despite the context already being cancelled")
+    }
+}
+
+var errBoom = errors.New("boom")
+
+type boomKit struct{}
+
+func (k *boomKit) Init(ic *InitContext) error { return errBoom }
+
+func TestInitAbortsOnFirstError(t *testing.T) {
+    r := NewRegistry()
+    r.Register(&boomKit{})
+    inited := false
+    r.Register(&trackedInitKit{inited: &inited})
+
+    err := r.Init()
+    if !errors.Is(err, errBoom) {
+        t.Fatalf("Init() err = %v, want it to wrap errBoom", err)
+    }
+    if inited {
+        t.Fatal("a kit queued after the failing one was still initialized")
+    }
+}
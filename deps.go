@@ -0,0 +1,141 @@
+package kits
+
+import (
+    "fmt"
+    "reflect"
+    "strings"
+)
+
+// CycleError is returned by Init when the declared Requires() of the
+// registered kits form a dependency cycle, making initialization order
+// impossible to determine.
+type CycleError struct {
+    Kits []Kit // the kits participating in the cycle, in cycle order; the first kit is repeated at the end to close the loop
+}
+
+func (e *CycleError) Error() string {
+    names := make([]string, len(e.Kits))
+    for i, k := range e.Kits {
+        names[i] = fmt.Sprintf("%T", k)
+    }
+    return fmt.Sprintf("kits: dependency cycle detected: %s", strings.Join(names, " -> "))
+}
+
+// UnsatisfiedError is returned by Init when a kit's Requires() names an
+// interface that no registered kit implements.
+type UnsatisfiedError struct {
+    Kit         Kit          // the kit declaring the requirement
+    Requirement reflect.Type // the required interface that's missing
+}
+
+func (e *UnsatisfiedError) Error() string {
+    return fmt.Sprintf("kits: %T requires %s, but no registered kit satisfies it", e.Kit, e.Requirement)
+}
+
+// requirer is implemented by kits that need other kits to be initialized
+// before them. Requires returns pointer-to-interface values in the same
+// shape Find accepts (e.g. []interface{}{(*Storage)(nil)}).
+type requirer interface {
+    Requires() []interface{}
+}
+
+// initOrder returns nodes topologically sorted so that every kit appears
+// after all of the kits it Requires(). Dependencies already satisfied by a
+// kit outside of nodes (i.e. previously initialized) don't affect ordering.
+func (r *Registry) initOrder(nodes []Kit) ([]Kit, error) {
+    // index nodes by their position, so we can build an adjacency list of
+    // edges "i depends on j".
+    adj := make([][]int, len(nodes))
+    for i, k := range nodes {
+        req, ok := k.(requirer)
+        if !ok {
+            continue
+        }
+
+        for _, e := range req.Requires() {
+            t := reflect.TypeOf(e).Elem()
+            deps, found := r.dependencies(nodes, t)
+            if !found {
+                return nil, &UnsatisfiedError{Kit: k, Requirement: t}
+            }
+            adj[i] = append(adj[i], deps...)
+        }
+    }
+
+    const (
+        white = iota
+        gray
+        black
+    )
+    color := make([]int, len(nodes))
+    var order []Kit
+    var stack []int
+
+    var visit func(i int) error
+    visit = func(i int) error {
+        switch color[i] {
+        case black:
+            return nil
+        case gray:
+            cycle := append(append([]int{}, stack[indexOf(stack, i):]...), i)
+            kits := make([]Kit, len(cycle))
+            for j, idx := range cycle {
+                kits[j] = nodes[idx]
+            }
+            return &CycleError{Kits: kits}
+        }
+
+        color[i] = gray
+        stack = append(stack, i)
+        for _, j := range adj[i] {
+            if err := visit(j); err != nil {
+                return err
+            }
+        }
+        stack = stack[:len(stack)-1]
+        color[i] = black
+        order = append(order, nodes[i])
+        return nil
+    }
+
+    for i := range nodes {
+        if err := visit(i); err != nil {
+            return nil, err
+        }
+    }
+    return order, nil
+}
+
+// dependencies returns the indices, within nodes, of every kit that
+// implements t. found reports whether any registered kit - inside or
+// outside of nodes - implements t at all; if not, t is unsatisfiable.
+func (r *Registry) dependencies(nodes []Kit, t reflect.Type) ([]int, bool) {
+    var idxs []int
+    found := false
+    for i, k := range nodes {
+        if reflect.TypeOf(k).Implements(t) {
+            idxs = append(idxs, i)
+            found = true
+        }
+    }
+    if found {
+        return idxs, true
+    }
+
+    for _, k := range r.kits {
+        if reflect.TypeOf(k).Implements(t) {
+            return nil, true
+        }
+    }
+    return nil, false
+}
+
+// indexOf returns the position of v within s.
+func indexOf(s []int, v int) int {
+    for i, x := range s {
+        if x == v {
+            return i
+        }
+    }
+    return -1
+}
@@ -0,0 +1,124 @@
+package kits
+
+import (
+    "errors"
+    "testing"
+)
+
+type fooer interface {
+    Foo()
+}
+
+type fooKit struct {
+    order *[]string
+}
+
+func (k *fooKit) Init() { *k.order = append(*k.order, "foo") }
+func (k *fooKit) Foo()  {}
+
+type barKit struct {
+    order *[]string
+}
+
+func (k *barKit) Init()                   { *k.order = append(*k.order, "bar") }
+func (k *barKit) Bar()                    {}
+func (k *barKit) Requires() []interface{} { return []interface{}{(*fooer)(nil)} }
+
+func TestInitOrdersByRequires(t *testing.T) {
+    var order []string
+    r := NewRegistry()
+    // register in the "wrong" order: the dependent kit before its dependency.
+    r.Register(&barKit{order: &order})
+    r.Register(&fooKit{order: &order})
+
+    if err := r.Init(); err != nil {
+        t.Fatalf("Init: %v", err)
+    }
+    if got := order; len(got) != 2 || got[0] != "foo" || got[1] != "bar" {
+        t.Fatalf("Init order = %v, want [foo bar]", got)
+    }
+}
+
+type aIface interface{ A() }
+type bIface interface{ B() }
+
+type aKit struct{}
+
+func (k *aKit) A()                      {}
+func (k *aKit) Requires() []interface{} { return []interface{}{(*bIface)(nil)} }
+
+type bKit struct{}
+
+func (k *bKit) B()                      {}
+func (k *bKit) Requires() []interface{} { return []interface{}{(*aIface)(nil)} }
+
+func TestInitDetectsCycle(t *testing.T) {
+    r := NewRegistry()
+    r.Register(&aKit{})
+    r.Register(&bKit{})
+
+    err := r.Init()
+    var cycleErr *CycleError
+    if !errors.As(err, &cycleErr) {
+        t.Fatalf("Init() err = %v, want *CycleError", err)
+    }
+}
+
+type missingIface interface{ Missing() }
+
+type needsMissingKit struct{}
+
+func (k *needsMissingKit) Requires() []interface{} { return []interface{}{(*missingIface)(nil)} }
+
+func TestInitDetectsUnsatisfiedRequirement(t *testing.T) {
+    r := NewRegistry()
+    r.Register(&needsMissingKit{})
+
+    err := r.Init()
+    var unsatisfiedErr *UnsatisfiedError
+    if !errors.As(err, &unsatisfiedErr) {
+        t.Fatalf("Init() err = %v, want *UnsatisfiedError", err)
+    }
+}
+
+type flagIface interface{ Flag() }
+
+type flagKit struct{ inited bool }
+
+func (k *flagKit) Init() { k.inited = true }
+func (k *flagKit) Flag() {}
+
+type needsFlagKit struct{ inited bool }
+
+func (k *needsFlagKit) Init()                   { k.inited = true }
+func (k *needsFlagKit) Requires() []interface{} { return []interface{}{(*flagIface)(nil)} }
+
+// TestInitRetriesAfterUnsatisfiedRequirement guards against a regression
+// where a kit left un-run by a failed Init() was silently dropped from the
+// registry, so a later Init() - once the missing dependency was registered -
+// would succeed without ever running the original kit.
+func TestInitRetriesAfterUnsatisfiedRequirement(t *testing.T) {
+    r := NewRegistry()
+    nk := &needsFlagKit{}
+    r.Register(nk)
+
+    if err := r.Init(); err == nil {
+        t.Fatal("Init() with missing dependency: got nil error")
+    }
+    if nk.inited {
+        t.Fatal("needsFlagKit.Init() ran despite its requirement being unsatisfied")
+    }
+
+    fk := &flagKit{}
+    r.Register(fk)
+
+    if err := r.Init(); err != nil {
+        t.Fatalf("Init() after registering the missing dependency: %v", err)
+    }
+    if !nk.inited {
+        t.Fatal("needsFlagKit was never initialized after its requirement was satisfied")
+    }
+    if !fk.inited {
+        t.Fatal("flagKit was never initialized")
+    }
+}
@@ -0,0 +1,132 @@
+package kits
+
+import (
+    "fmt"
+    "reflect"
+    "sort"
+    "strings"
+)
+
+// maxNearMisses caps how many candidate kits a NotFoundError reports, so the
+// error stays readable even when many kits are registered.
+const maxNearMisses = 3
+
+// MethodMismatch describes a single method of a requested interface that a
+// candidate kit either doesn't implement at all, or implements with a
+// different signature.
+type MethodMismatch struct {
+    Name     string       // method name
+    Expected reflect.Type // the signature the interface requires
+    Actual   reflect.Type // the candidate's signature, or nil if missing entirely
+}
+
+func (m MethodMismatch) String() string {
+    if m.Actual == nil {
+        return fmt.Sprintf("%s: missing", m.Name)
+    }
+    return fmt.Sprintf("%s: have %s, want %s", m.Name, m.Actual, m.Expected)
+}
+
+// NearMiss is a registered kit that doesn't implement a requested interface,
+// along with the specific methods that are missing or mismatched.
+type NearMiss struct {
+    Kit     Kit
+    Methods []MethodMismatch
+}
+
+// NotFoundError is returned by Find/FindAll when no registered kit
+// implements the requested interface. Unlike the bare NotFoundErr sentinel,
+// it lists the closest registered kits and explains exactly which methods
+// they're missing or got wrong, to make debugging interface mismatches
+// straightforward.
+type NotFoundError struct {
+    Type       reflect.Type // the requested interface
+    Name       string       // the id searched, if this came from FindNamed
+    NearMisses []NearMiss   // closest registered kits, most similar first
+}
+
+func (e *NotFoundError) Error() string {
+    msg := fmt.Sprintf("kits: matching package not found: %s", e.Type)
+    if e.Name != "" {
+        msg = fmt.Sprintf("%s named %q", msg, e.Name)
+    }
+    if len(e.NearMisses) == 0 {
+        return msg
+    }
+
+    var b strings.Builder
+    b.WriteString(msg)
+    b.WriteString("; closest registered kits:")
+    for _, nm := range e.NearMisses {
+        fmt.Fprintf(&b, "\n  %T:", nm.Kit)
+        for _, mm := range nm.Methods {
+            fmt.Fprintf(&b, "\n    %s", mm)
+        }
+    }
+    return b.String()
+}
+
+// Unwrap allows errors.Is(err, NotFoundErr) to keep working for callers that
+// only care whether a kit was found, without inspecting the near misses.
+func (e *NotFoundError) Unwrap() error {
+    return NotFoundErr
+}
+
+// nearMisses ranks every candidate kit by how closely it matches t, for use
+// in a NotFoundError once find() has determined no kit implements t outright.
+func nearMisses(kits []Kit, t reflect.Type) []NearMiss {
+    all := make([]NearMiss, len(kits))
+    for i, k := range kits {
+        all[i] = NearMiss{Kit: k, Methods: methodMismatches(k, t)}
+    }
+
+    sort.SliceStable(all, func(i, j int) bool {
+        return len(all[i].Methods) < len(all[j].Methods)
+    })
+
+    if len(all) > maxNearMisses {
+        all = all[:maxNearMisses]
+    }
+    return all
+}
+
+// methodMismatches reports which methods of the interface t are missing or
+// mismatched on the candidate kit k.
+func methodMismatches(k Kit, t reflect.Type) []MethodMismatch {
+    vt := reflect.TypeOf(k)
+
+    var mismatches []MethodMismatch
+    for i := 0; i < t.NumMethod(); i++ {
+        want := t.Method(i)
+        have, ok := vt.MethodByName(want.Name)
+        if !ok {
+            mismatches = append(mismatches, MethodMismatch{Name: want.Name, Expected: want.Type})
+            continue
+        }
+
+        actual := have.Type
+        if vt.Kind() != reflect.Interface {
+            // Type.MethodByName on a concrete type includes the receiver as
+            // the first argument; drop it so it's comparable to want.Type.
+            actual = withoutReceiver(actual)
+        }
+        if actual != want.Type {
+            mismatches = append(mismatches, MethodMismatch{Name: want.Name, Expected: want.Type, Actual: actual})
+        }
+    }
+    return mismatches
+}
+
+// withoutReceiver strips the leading receiver argument from a method
+// function type, so it matches the shape of an interface method's Type.
+func withoutReceiver(t reflect.Type) reflect.Type {
+    ins := make([]reflect.Type, 0, t.NumIn()-1)
+    for i := 1; i < t.NumIn(); i++ {
+        ins = append(ins, t.In(i))
+    }
+    outs := make([]reflect.Type, 0, t.NumOut())
+    for i := 0; i < t.NumOut(); i++ {
+        outs = append(outs, t.Out(i))
+    }
+    return reflect.FuncOf(ins, outs, t.IsVariadic())
+}
@@ -0,0 +1,80 @@
+package kits
+
+import (
+    "errors"
+    "testing"
+)
+
+type greeter interface {
+    Greet() string
+}
+
+// badSigGreeter implements a Greet method, but with the wrong signature -
+// it should surface as a mismatch, not a missing method.
+type badSigGreeter struct{}
+
+func (g *badSigGreeter) Greet() {}
+
+func TestFindNotFoundReportsSignatureMismatch(t *testing.T) {
+    r := NewRegistry()
+    r.Register(&badSigGreeter{})
+
+    var g greeter
+    err := r.Find(&g)
+
+    var nfe *NotFoundError
+    if !errors.As(err, &nfe) {
+        t.Fatalf("Find() err = %v, want *NotFoundError", err)
+    }
+    if len(nfe.NearMisses) != 1 {
+        t.Fatalf("NearMisses = %v, want 1 entry", nfe.NearMisses)
+    }
+    mm := nfe.NearMisses[0].Methods
+    if len(mm) != 1 || mm[0].Name != "Greet" || mm[0].Actual == nil {
+        t.Fatalf("Methods = %+v, want a single mismatched (not missing) Greet", mm)
+    }
+    if !errors.Is(err, NotFoundErr) {
+        t.Fatal("errors.Is(err, NotFoundErr) = false, want true")
+    }
+}
+
+// unrelatedKit doesn't implement greeter at all - Greet is entirely missing.
+type unrelatedKit struct{}
+
+func (k *unrelatedKit) Unrelated() {}
+
+func TestFindNotFoundReportsMissingMethod(t *testing.T) {
+    r := NewRegistry()
+    r.Register(&unrelatedKit{})
+
+    var g greeter
+    err := r.Find(&g)
+
+    var nfe *NotFoundError
+    if !errors.As(err, &nfe) {
+        t.Fatalf("Find() err = %v, want *NotFoundError", err)
+    }
+    mm := nfe.NearMisses[0].Methods
+    if len(mm) != 1 || mm[0].Name != "Greet" || mm[0].Actual != nil {
+        t.Fatalf("Methods = %+v, want a single missing Greet", mm)
+    }
+}
+
+func TestFindNamedNotFoundReportsNearMisses(t *testing.T) {
+    r := NewRegistry()
+    r.RegisterNamed("a", &badSigGreeter{})
+
+    var g greeter
+    err := r.FindNamed("a", &g)
+
+    var nfe *NotFoundError
+    if !errors.As(err, &nfe) {
+        t.Fatalf("FindNamed() err = %v, want *NotFoundError", err)
+    }
+    if nfe.Name != "a" {
+        t.Fatalf("NotFoundError.Name = %q, want %q", nfe.Name, "a")
+    }
+    if len(nfe.NearMisses) != 1 {
+        t.Fatalf("NearMisses = %v, want 1 entry scoped to id %q", nfe.NearMisses, "a")
+    }
+}
@@ -1,6 +1,7 @@
 package kits
 
 import (
+    "context"
     "fmt"
     "reflect"
 )
@@ -16,35 +17,118 @@ var NotFoundErr = fmt.Errorf("pkgs: matching package not found")
 // different order for overrides.
 type Kit interface{}
 
-// global registeries
-var kits = []Kit{}
-var uninited = []Kit{} // list of registered but yet uninitialized kits
+// Registry holds its own isolated set of registered kits. Most programs only
+// ever need the package-level functions below, which operate on the Default
+// registry, but a Registry is useful whenever you need an isolated kit set -
+// for example in tests that register mock kits and shouldn't pollute global
+// state, or when embedding kits inside a larger app that manages its own
+// sub-systems.
+type Registry struct {
+    kits     []Kit
+    names    []string // names[i] is the id kits[i] was registered under, or "" if unnamed
+    uninited []Kit    // list of registered but yet uninitialized kits
+    inited   []Kit    // kits whose Init() has completed, in init order
+}
+
+// NewRegistry returns an empty, ready to use Registry.
+func NewRegistry() *Registry {
+    return &Registry{}
+}
+
+// Fork returns a new, independent Registry pre-populated with all of the
+// kits already registered on r. Kits r has already finished initializing are
+// carried over as already initialized; any kit still pending on r (queued
+// for a future r.Init()) is copied over pending as well, so the child's own
+// Init() will still run it. The child can Register its own additional kits
+// - commonly mocks or overrides - without affecting r or any other Registry
+// forked from it.
+func (r *Registry) Fork() *Registry {
+    child := NewRegistry()
+    child.kits = append([]Kit{}, r.kits...)
+    child.names = append([]string{}, r.names...)
+    child.uninited = append([]Kit{}, r.uninited...)
+    return child
+}
 
 // Register a new kit to be accessible via Find(). All registerations must
 // happen upon initialization, before the call to Init() or Find()
-func Register(k Kit) {
-    kits = append(kits, k)
-    uninited = append(uninited, k)
+func (r *Registry) Register(k Kit) {
+    r.register("", k)
+}
+
+// register records k as a newly registered, uninitialized kit, under the
+// given id (possibly empty, for unnamed kits).
+func (r *Registry) register(id string, k Kit) {
+    r.kits = append(r.kits, k)
+    r.names = append(r.names, id)
+    r.uninited = append(r.uninited, k)
 }
 
 // Init all of the registered, but yet uninitialized kits, by running their
 // Init() function, if exists. This is a safe place to call all of the Find()
 // functions because we're guaranteed that all of the relevant kits were
 // registered and are thus accessible.
-func Init() {
-    var k Kit
-    for len(uninited) > 0 {
-        k, uninited = uninited[0], uninited[1:]
-        initer, ok := k.(interface{ Init() })
-        if ok {
-            initer.Init()
+//
+// Kits are initialized in dependency order: a kit that implements Requires()
+// is guaranteed to be initialized only after every kit it requires. See
+// CycleError and UnsatisfiedError for the errors returned when that ordering is
+// impossible.
+//
+// A kit may implement the richer Init(ctx *InitContext) error instead of the
+// parameterless Init(), to receive a context.Context and properties passed
+// via WithContext / WithProperty options. Init aborts on the first kit that
+// returns an error, wrapping it to identify the failing kit; only the kits
+// that completed Init() beforehand are recorded for a subsequent Shutdown.
+//
+// A kit is only considered initialized once its Init() has actually run to
+// completion. Any kit left un-run because of a CycleError, an UnsatisfiedError,
+// a cancelled context, or another kit's Init() returning an error stays in
+// the pending set, so a later call to Init() will retry it instead of
+// silently dropping it.
+func (r *Registry) Init(opts ...InitOption) error {
+    ic := &InitContext{Context: context.Background(), Registry: r}
+    for _, opt := range opts {
+        opt(ic)
+    }
+
+    order, err := r.initOrder(r.uninited)
+    if err != nil {
+        return err
+    }
+    r.uninited = nil
+
+    for i, k := range order {
+        select {
+        case <-ic.Context.Done():
+            r.uninited = append(order[i:], r.uninited...)
+            return fmt.Errorf("kits: init aborted: %w", ic.Context.Err())
+        default:
+        }
+
+        if err := initKit(k, ic); err != nil {
+            r.uninited = append(order[i:], r.uninited...)
+            return fmt.Errorf("kits: %T failed to init: %w", k, err)
         }
+        r.inited = append(r.inited, k)
     }
+    return nil
+}
+
+// initKit runs a single kit's Init, preferring the context-aware
+// Init(ctx *InitContext) error over the plain Init() when both are present.
+func initKit(k Kit, ic *InitContext) error {
+    if initer, ok := k.(interface{ Init(ctx *InitContext) error }); ok {
+        return initer.Init(ic)
+    }
+    if initer, ok := k.(interface{ Init() }); ok {
+        initer.Init()
+    }
+    return nil
 }
 
 // MustFind is similar to Find but panics on error.
-func MustFind(e interface{}) {
-    err := Find(e)
+func (r *Registry) MustFind(e interface{}) {
+    err := r.Find(e)
     if err != nil {
         panic(err)
     }
@@ -61,13 +145,8 @@ func MustFind(e interface{}) {
 // you don't have a preference as to which specific kits is desired, and allows
 // for kit overrides.
 //
-// Alternatively, the argument can be a pointer to a slice of interfaces, in
-// which case all of the matching kits will be assigned to the slice, allowing
-// the user to devise their own approach to prioritizing which specific kit
-// should be used, normally by using examining other functions exposed on the
-// kits to differentiate between them (like Version(), Type(), etc.). It's up
-// to the individual kits to provide the API required for such prioritization.
-func Find(e interface{}) error {
+// See FindAll if you'd like every matching kit instead of just the last one.
+func (r *Registry) Find(e interface{}) error {
     if e == nil {
         return UnassignableErr
     }
@@ -80,24 +159,57 @@ func Find(e interface{}) error {
 
     // pointer of..
     v, t = v.Elem(), t.Elem()
-    vals, err := find(t)
+    vals, err := r.find(t)
     if err != nil {
         return err
     }
 
-    lastv := vals[len(vals) - 1]
+    lastv := vals[len(vals)-1]
     v.Set(lastv)
     return nil
 }
 
+// FindAll is similar to Find, but the argument must be a pointer to a slice
+// of interfaces, and every matching kit is assigned to it, in registration
+// order, rather than only the most recently registered one. It's up to the
+// caller to devise their own approach to prioritizing between the matches,
+// normally by examining other functions exposed on the kits to differentiate
+// between them (like Version(), Type(), etc.). It's up to the individual
+// kits to provide the API required for such prioritization.
+func (r *Registry) FindAll(e interface{}) error {
+    if e == nil {
+        return UnassignableErr
+    }
+
+    v := reflect.ValueOf(e)
+    t := v.Type()
+    if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Slice {
+        return UnassignableErr
+    }
+
+    // pointer of a slice of..
+    v, t = v.Elem(), t.Elem().Elem()
+    vals, err := r.find(t)
+    if err != nil {
+        return err
+    }
+
+    s := reflect.MakeSlice(v.Type(), 0, len(vals))
+    for _, val := range vals {
+        s = reflect.Append(s, val)
+    }
+    v.Set(s)
+    return nil
+}
+
 // finds all of the kits that implements the provided type.
-func find(t reflect.Type) ([]reflect.Value, error) {
+func (r *Registry) find(t reflect.Type) ([]reflect.Value, error) {
     if t.Kind() != reflect.Interface {
         return nil, NonInterfaceErr
     }
 
     var matched []reflect.Value
-    for _, k := range kits {
+    for _, k := range r.kits {
         v := reflect.ValueOf(k)
         if v.Type().Implements(t) {
             matched = append(matched, v)
@@ -105,8 +217,38 @@ func find(t reflect.Type) ([]reflect.Value, error) {
     }
 
     if matched == nil {
-        return nil, fmt.Errorf("kits: matching package not found: %s", t)
+        return nil, &NotFoundError{Type: t, NearMisses: nearMisses(r.kits, t)}
     }
 
     return matched, nil
 }
+
+// Default is the package-level Registry used by Register, Init, Find,
+// MustFind and FindAll below. Most programs only ever need this one
+// registry; reach for NewRegistry directly when you need an isolated kit set.
+var Default = NewRegistry()
+
+// Register a new kit on the Default registry. See Registry.Register.
+func Register(k Kit) {
+    Default.Register(k)
+}
+
+// Init the Default registry. See Registry.Init.
+func Init(opts ...InitOption) error {
+    return Default.Init(opts...)
+}
+
+// MustFind is similar to Find but panics on error.
+func MustFind(e interface{}) {
+    Default.MustFind(e)
+}
+
+// Find a kit on the Default registry. See Registry.Find.
+func Find(e interface{}) error {
+    return Default.Find(e)
+}
+
+// FindAll kits on the Default registry. See Registry.FindAll.
+func FindAll(e interface{}) error {
+    return Default.FindAll(e)
+}
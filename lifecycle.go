@@ -0,0 +1,45 @@
+package kits
+
+import (
+    "context"
+    "errors"
+)
+
+// closer is implemented by kits that need to release resources - DB
+// handles, flushed queues, cancelled goroutines - acquired during Init().
+type closer interface {
+    Close() error
+}
+
+// ctxShutdowner is the context-aware equivalent of closer, preferred over it
+// when a kit implements both.
+type ctxShutdowner interface {
+    Shutdown(ctx context.Context) error
+}
+
+// Shutdown walks the kits that were successfully initialized, in reverse
+// init order, and calls their optional Close() error or
+// Shutdown(context.Context) error method - Shutdown is preferred when a kit
+// implements both. Errors from every kit are aggregated via errors.Join
+// rather than stopping at the first. Only kits that actually completed
+// Init() are shut down, so a partial Init() failure only tears down what
+// actually started.
+func (r *Registry) Shutdown(ctx context.Context) error {
+    var err error
+    for i := len(r.inited) - 1; i >= 0; i-- {
+        k := r.inited[i]
+        switch c := k.(type) {
+        case ctxShutdowner:
+            err = errors.Join(err, c.Shutdown(ctx))
+        case closer:
+            err = errors.Join(err, c.Close())
+        }
+    }
+    r.inited = nil
+    return err
+}
+
+// Shutdown tears down the Default registry. See Registry.Shutdown.
+func Shutdown(ctx context.Context) error {
+    return Default.Shutdown(ctx)
+}
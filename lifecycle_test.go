@@ -0,0 +1,109 @@
+package kits
+
+import (
+    "context"
+    "errors"
+    "testing"
+)
+
+type closeKit struct {
+    order *[]string
+    name  string
+}
+
+func (k *closeKit) Init()       { *k.order = append(*k.order, "init:"+k.name) }
+func (k *closeKit) Close() error {
+    *k.order = append(*k.order, "close:"+k.name)
+    return nil
+}
+
+type ctxShutdownKit struct {
+    order *[]string
+    name  string
+}
+
+func (k *ctxShutdownKit) Init() { *k.order = append(*k.order, "init:"+k.name) }
+func (k *ctxShutdownKit) Close() error {
+    *k.order = append(*k.order, "close:"+k.name)
+    return nil
+}
+func (k *ctxShutdownKit) Shutdown(ctx context.Context) error {
+    *k.order = append(*k.order, "shutdown:"+k.name)
+    return nil
+}
+
+func TestShutdownReverseOrderPrefersShutdownOverClose(t *testing.T) {
+    var order []string
+    r := NewRegistry()
+    a := &closeKit{order: &order, name: "a"}
+    b := &ctxShutdownKit{order: &order, name: "b"}
+    r.Register(a)
+    r.Register(b)
+
+    if err := r.Init(); err != nil {
+        t.Fatalf("Init: %v", err)
+    }
+    if err := r.Shutdown(context.Background()); err != nil {
+        t.Fatalf("Shutdown: %v", err)
+    }
+
+    want := []string{"init:a", "init:b", "shutdown:b", "close:a"}
+    if len(order) != len(want) {
+        t.Fatalf("order = %v, want %v", order, want)
+    }
+    for i := range want {
+        if order[i] != want[i] {
+            t.Fatalf("order = %v, want %v", order, want)
+        }
+    }
+}
+
+type failCloseKit struct{ err error }
+
+func (k *failCloseKit) Init()       {}
+func (k *failCloseKit) Close() error { return k.err }
+
+func TestShutdownAggregatesErrors(t *testing.T) {
+    r := NewRegistry()
+    err1 := errors.New("err1")
+    err2 := errors.New("err2")
+    r.Register(&failCloseKit{err: err1})
+    r.Register(&failCloseKit{err: err2})
+
+    if err := r.Init(); err != nil {
+        t.Fatalf("Init: %v", err)
+    }
+
+    err := r.Shutdown(context.Background())
+    if !errors.Is(err, err1) || !errors.Is(err, err2) {
+        t.Fatalf("Shutdown() = %v, want an aggregate of both errors", err)
+    }
+}
+
+type trackedCloseKit struct{ closed *bool }
+
+func (k *trackedCloseKit) Init()       {}
+func (k *trackedCloseKit) Close() error { *k.closed = true; return nil }
+
+type ctxFailKit struct{}
+
+func (k *ctxFailKit) Init(ic *InitContext) error { return errors.New("boom") }
+
+// TestShutdownOnlyTearsDownCompletedInit verifies a partial Init() failure
+// only tears down the kits that actually completed Init() beforehand.
+func TestShutdownOnlyTearsDownCompletedInit(t *testing.T) {
+    r := NewRegistry()
+    closed := false
+    r.Register(&trackedCloseKit{closed: &closed})
+    r.Register(&ctxFailKit{})
+
+    if err := r.Init(); err == nil {
+        t.Fatal("Init() with a failing kit: got nil error")
+    }
+    if err := r.Shutdown(context.Background()); err != nil {
+        t.Fatalf("Shutdown: %v", err)
+    }
+    if !closed {
+        t.Fatal("the kit that completed Init() before the failure was never shut down")
+    }
+}
@@ -0,0 +1,110 @@
+package kits
+
+import (
+    "reflect"
+)
+
+// RegisterNamed is similar to Register, but associates the kit with an id so
+// it can later be selected deterministically via FindNamed, instead of only
+// via "last registered wins". This allows multiple implementations of the
+// same interface to coexist - for example multiple storage backends - with
+// callers picking the one they want by id rather than by registration order.
+func (r *Registry) RegisterNamed(id string, k Kit) {
+    r.register(id, k)
+}
+
+// FindNamed is similar to Find, but only considers kits that were registered
+// under the given id via RegisterNamed. A NotFoundErr-shaped error is
+// returned if no kit with that id implements the requested interface.
+func (r *Registry) FindNamed(id string, e interface{}) error {
+    if e == nil {
+        return UnassignableErr
+    }
+
+    v := reflect.ValueOf(e)
+    t := v.Type()
+    if t.Kind() != reflect.Ptr {
+        return UnassignableErr
+    }
+
+    // pointer of..
+    v, t = v.Elem(), t.Elem()
+    vals, err := r.findNamed(id, t)
+    if err != nil {
+        return err
+    }
+
+    lastv := vals[len(vals)-1]
+    v.Set(lastv)
+    return nil
+}
+
+// Names returns the ids of every registered kit that implements the
+// interface pointed to by e, in registration order. Kits registered via the
+// unnamed Register have an empty id.
+func (r *Registry) Names(e interface{}) []string {
+    if e == nil {
+        return nil
+    }
+
+    v := reflect.ValueOf(e)
+    t := v.Type()
+    if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+        return nil
+    }
+    t = t.Elem()
+
+    var names []string
+    for i, k := range r.kits {
+        if reflect.TypeOf(k).Implements(t) {
+            names = append(names, r.names[i])
+        }
+    }
+    return names
+}
+
+// finds all of the kits registered under id that implement the provided
+// type.
+func (r *Registry) findNamed(id string, t reflect.Type) ([]reflect.Value, error) {
+    if t.Kind() != reflect.Interface {
+        return nil, NonInterfaceErr
+    }
+
+    var named []Kit
+    var matched []reflect.Value
+    for i, k := range r.kits {
+        if r.names[i] != id {
+            continue
+        }
+        named = append(named, k)
+
+        v := reflect.ValueOf(k)
+        if v.Type().Implements(t) {
+            matched = append(matched, v)
+        }
+    }
+
+    if matched == nil {
+        return nil, &NotFoundError{Type: t, Name: id, NearMisses: nearMisses(named, t)}
+    }
+
+    return matched, nil
+}
+
+// RegisterNamed registers a new kit on the Default registry under id. See
+// Registry.RegisterNamed.
+func RegisterNamed(id string, k Kit) {
+    Default.RegisterNamed(id, k)
+}
+
+// FindNamed finds a kit named id on the Default registry. See
+// Registry.FindNamed.
+func FindNamed(id string, e interface{}) error {
+    return Default.FindNamed(id, e)
+}
+
+// Names returns the ids of the Default registry's kits matching e. See
+// Registry.Names.
+func Names(e interface{}) []string {
+    return Default.Names(e)
+}
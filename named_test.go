@@ -0,0 +1,48 @@
+package kits
+
+import "testing"
+
+type storage interface {
+    Store() string
+}
+
+type memStorage struct{}
+
+func (k *memStorage) Store() string { return "mem" }
+
+type diskStorage struct{}
+
+func (k *diskStorage) Store() string { return "disk" }
+
+func TestFindNamedSelectsById(t *testing.T) {
+    r := NewRegistry()
+    r.RegisterNamed("mem", &memStorage{})
+    r.RegisterNamed("disk", &diskStorage{})
+
+    var mem storage
+    if err := r.FindNamed("mem", &mem); err != nil {
+        t.Fatalf("FindNamed(mem): %v", err)
+    }
+    if got := mem.Store(); got != "mem" {
+        t.Fatalf("FindNamed(mem).Store() = %q, want %q", got, "mem")
+    }
+
+    var disk storage
+    if err := r.FindNamed("disk", &disk); err != nil {
+        t.Fatalf("FindNamed(disk): %v", err)
+    }
+    if got := disk.Store(); got != "disk" {
+        t.Fatalf("FindNamed(disk).Store() = %q, want %q", got, "disk")
+    }
+}
+
+func TestNamesEnumeratesIds(t *testing.T) {
+    r := NewRegistry()
+    r.RegisterNamed("mem", &memStorage{})
+    r.RegisterNamed("disk", &diskStorage{})
+
+    names := r.Names((*storage)(nil))
+    if len(names) != 2 || names[0] != "mem" || names[1] != "disk" {
+        t.Fatalf("Names() = %v, want [mem disk]", names)
+    }
+}
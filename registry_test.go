@@ -0,0 +1,109 @@
+package kits
+
+import "testing"
+
+type pinger interface {
+    Ping() string
+}
+
+type pingKit struct{ inited bool }
+
+func (k *pingKit) Init()        { k.inited = true }
+func (k *pingKit) Ping() string { return "pong" }
+
+type altPingKit struct{ inited bool }
+
+func (k *altPingKit) Init()        { k.inited = true }
+func (k *altPingKit) Ping() string { return "alt-pong" }
+
+func TestRegisterFindRoundTrip(t *testing.T) {
+    r := NewRegistry()
+    k1 := &pingKit{}
+    k2 := &altPingKit{}
+    r.Register(k1)
+    r.Register(k2)
+
+    if err := r.Init(); err != nil {
+        t.Fatalf("Init: %v", err)
+    }
+    if !k1.inited || !k2.inited {
+        t.Fatal("not every registered kit was initialized")
+    }
+
+    // Find returns the most recently registered match.
+    var p pinger
+    if err := r.Find(&p); err != nil {
+        t.Fatalf("Find: %v", err)
+    }
+    if got := p.Ping(); got != "alt-pong" {
+        t.Fatalf("Find() = %q, want %q", got, "alt-pong")
+    }
+
+    // FindAll returns every match, in registration order.
+    var all []pinger
+    if err := r.FindAll(&all); err != nil {
+        t.Fatalf("FindAll: %v", err)
+    }
+    if len(all) != 2 || all[0].Ping() != "pong" || all[1].Ping() != "alt-pong" {
+        t.Fatalf("FindAll() = %v, want [pong alt-pong]", all)
+    }
+
+    // MustFind succeeds silently when a match exists.
+    var p2 pinger
+    r.MustFind(&p2)
+    if got := p2.Ping(); got != "alt-pong" {
+        t.Fatalf("MustFind() = %q, want %q", got, "alt-pong")
+    }
+}
+
+func TestMustFindPanicsWhenNotFound(t *testing.T) {
+    r := NewRegistry()
+
+    defer func() {
+        if recover() == nil {
+            t.Fatal("MustFind() did not panic for an unmatched interface")
+        }
+    }()
+
+    var p pinger
+    r.MustFind(&p)
+}
+
+// TestForkCarriesOverPendingKits guards against a regression where a kit
+// registered but not yet initialized on the parent - the realistic case,
+// where kits self-register via package init() and Registry.Init() is
+// invoked later, per test, on the fork - was dropped from the forked
+// child's pending set, so the child's Init() silently never ran it even
+// though Find/FindAll still returned it.
+func TestForkCarriesOverPendingKits(t *testing.T) {
+    r := NewRegistry()
+    k := &pingKit{}
+    r.Register(k)
+
+    child := r.Fork()
+    if err := child.Init(); err != nil {
+        t.Fatalf("child.Init(): %v", err)
+    }
+    if !k.inited {
+        t.Fatal("pending kit was never initialized by the forked child's Init()")
+    }
+}
+
+func TestForkIsolatesRegistrations(t *testing.T) {
+    r := NewRegistry()
+    r.Register(&pingKit{})
+    if err := r.Init(); err != nil {
+        t.Fatalf("Init: %v", err)
+    }
+
+    child := r.Fork()
+    child.Register(&altPingKit{})
+
+    var all []pinger
+    if err := r.FindAll(&all); err != nil {
+        t.Fatalf("FindAll: %v", err)
+    }
+    if len(all) != 1 {
+        t.Fatalf("parent saw %d kits after a registration on its fork, want 1", len(all))
+    }
+}